@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/amangirdhar210/inventoryClient/internal/alerts"
+)
+
+// loadAlerts reads alerts.yaml, if present, and wires up an Evaluator with
+// whichever notifiers the config enables. A missing or unconfigured file
+// simply leaves alerting disabled.
+func (c *Client) loadAlerts() {
+	path, err := alerts.DefaultPath()
+	if err != nil {
+		return
+	}
+	cfg, err := alerts.LoadConfig(path)
+	if err != nil {
+		log.Printf("warning: could not read alerts config: %v", err)
+		return
+	}
+
+	c.alertsPath = path
+	c.alertsCfg = cfg
+	c.alertEvaluator = alerts.NewEvaluator(cfg, notifiersFor(cfg)...)
+}
+
+func notifiersFor(cfg *alerts.Config) []alerts.Notifier {
+	var notifiers []alerts.Notifier
+	if cfg.NotifyStdout {
+		notifiers = append(notifiers, alerts.StdoutNotifier{Out: os.Stdout})
+	}
+	if cfg.NotifyWebhook != "" {
+		notifiers = append(notifiers, alerts.WebhookNotifier{URL: cfg.NotifyWebhook})
+	}
+	if cfg.NotifyDesktop {
+		notifiers = append(notifiers, alerts.DefaultDesktopNotifier)
+	}
+	if cfg.SMTPHost != "" {
+		notifiers = append(notifiers, alerts.EmailNotifier{
+			Host: cfg.SMTPHost,
+			Port: cfg.SMTPPort,
+			From: cfg.SMTPFrom,
+			To:   cfg.SMTPTo,
+		})
+	}
+	return notifiers
+}
+
+// startAlertPolling begins the background low-stock check, if alerts.yaml
+// configured a poll interval. It only ever starts once per process.
+func (c *Client) startAlertPolling() {
+	if c.alertEvaluator == nil || c.alertsCfg.PollInterval <= 0 || c.alertPollStarted {
+		return
+	}
+	c.alertPollStarted = true
+
+	go func() {
+		ticker := time.NewTicker(c.alertsCfg.PollInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			products, _, err := c.fetchAllProducts()
+			if err != nil {
+				continue
+			}
+			c.alertEvaluator.Evaluate(products)
+		}
+	}()
+}
+
+// manageAlertThresholds lets the user view and edit reorder thresholds,
+// saving changes back to alerts.yaml.
+func (c *Client) manageAlertThresholds(reader *bufio.Reader) error {
+	if c.alertsCfg == nil {
+		path, err := alerts.DefaultPath()
+		if err != nil {
+			return err
+		}
+		c.alertsPath = path
+		c.alertsCfg = &alerts.Config{Thresholds: map[string]int{}}
+	}
+	cfg := c.alertsCfg
+
+	fmt.Println("\n-> Low-Stock Alert Thresholds")
+	fmt.Printf("   Default threshold: %d\n", cfg.DefaultThreshold)
+	if len(cfg.Thresholds) == 0 {
+		fmt.Println("   No per-product thresholds configured.")
+	} else {
+		for id, threshold := range cfg.Thresholds {
+			fmt.Printf("   %s: %d\n", id, threshold)
+		}
+	}
+
+	id := readString(reader, "   Enter Product ID to set (or \"*\" for the default, blank to leave unchanged): ")
+	if id == "" {
+		return nil
+	}
+	thresholdStr := readString(reader, "   Enter new threshold: ")
+	threshold, err := strconv.Atoi(thresholdStr)
+	if err != nil {
+		return fmt.Errorf("invalid threshold: %w", err)
+	}
+
+	if id == "*" {
+		cfg.DefaultThreshold = threshold
+	}
+	if cfg.Thresholds == nil {
+		cfg.Thresholds = map[string]int{}
+	}
+	cfg.Thresholds[id] = threshold
+
+	if err := cfg.Save(c.alertsPath); err != nil {
+		return err
+	}
+	c.alertEvaluator = alerts.NewEvaluator(cfg, notifiersFor(cfg)...)
+	fmt.Println("   Threshold saved.")
+	return nil
+}
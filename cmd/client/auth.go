@@ -0,0 +1,67 @@
+package main
+
+import (
+	"log"
+
+	"github.com/amangirdhar210/inventoryClient/internal/auth"
+)
+
+const (
+	keyringService = "inventory-client"
+	keyringUser    = "token"
+)
+
+// LoadToken restores the session token saved by a previous run, preferring
+// the OS keyring and falling back to the credentials file.
+func (c *Client) LoadToken() {
+	if token, err := auth.DefaultKeyring.Get(keyringService, keyringUser); err == nil && token != "" {
+		c.setToken(token)
+		return
+	}
+
+	path, err := auth.Path()
+	if err != nil {
+		return
+	}
+	creds, ok, err := auth.Load(path)
+	if err != nil {
+		log.Printf("warning: could not read saved credentials: %v", err)
+		return
+	}
+	if ok {
+		c.setToken(creds.Token)
+	}
+}
+
+// SaveToken persists the current token to disk, and mirrors it into the OS
+// keyring when one is available.
+func (c *Client) SaveToken() error {
+	token := c.getToken()
+
+	path, err := auth.Path()
+	if err != nil {
+		return err
+	}
+	if err := auth.Save(path, auth.Credentials{Token: token}); err != nil {
+		return err
+	}
+
+	if err := auth.DefaultKeyring.Set(keyringService, keyringUser, token); err != nil && err != auth.ErrKeyringUnavailable {
+		log.Printf("warning: could not save token to OS keyring: %v", err)
+	}
+	return nil
+}
+
+// ClearToken drops the token from memory, disk, and the OS keyring.
+func (c *Client) ClearToken() {
+	c.setToken("")
+
+	if path, err := auth.Path(); err == nil {
+		if err := auth.Clear(path); err != nil {
+			log.Printf("warning: could not remove saved credentials: %v", err)
+		}
+	}
+	if err := auth.DefaultKeyring.Delete(keyringService, keyringUser); err != nil && err != auth.ErrKeyringUnavailable {
+		log.Printf("warning: could not clear OS keyring entry: %v", err)
+	}
+}
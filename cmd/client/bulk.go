@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/amangirdhar210/inventoryClient/internal/bulk"
+)
+
+// importProductsInteractive prompts for a CSV/JSON file and imports it
+// concurrently, printing a summary and writing any rejected rows next to
+// the source file.
+func (c *Client) importProductsInteractive(reader *bufio.Reader) error {
+	fmt.Println("\n-> Importing Products...")
+	path := readString(reader, "   Enter path to CSV or JSON file: ")
+	return c.importProducts(path, 8, false)
+}
+
+func (c *Client) importProducts(path string, parallel int, dryRun bool) error {
+	products, err := loadProductsFile(path)
+	if err != nil {
+		return err
+	}
+
+	result := bulk.ImportProducts(products, c.makeRequest, bulk.ImportOptions{Parallel: parallel, DryRun: dryRun})
+
+	fmt.Printf("\n<- Import finished: %d succeeded, %d failed.\n", result.Succeeded, result.Failed)
+	if len(result.Rejects) > 0 {
+		rejectsPath := path + ".rejects.csv"
+		f, err := os.Create(rejectsPath)
+		if err != nil {
+			return fmt.Errorf("writing rejects file: %w", err)
+		}
+		defer f.Close()
+		if err := bulk.WriteRejects(f, result.Rejects); err != nil {
+			return fmt.Errorf("writing rejects file: %w", err)
+		}
+		fmt.Printf("   Rejected rows written to %s\n", rejectsPath)
+	}
+	return nil
+}
+
+// exportProductsInteractive prompts for a destination and format, then
+// streams the current catalog to it.
+func (c *Client) exportProductsInteractive(reader *bufio.Reader) error {
+	fmt.Println("\n-> Exporting Products...")
+	dest := readString(reader, "   Enter output path (blank for stdout): ")
+	format := readString(reader, "   Enter format (csv/ndjson): ")
+	return c.exportProducts(dest, format)
+}
+
+func (c *Client) exportProducts(dest, format string) error {
+	products, _, err := c.fetchAllProducts()
+	if err != nil {
+		return err
+	}
+
+	w := os.Stdout
+	if dest != "" {
+		f, err := os.Create(dest)
+		if err != nil {
+			return fmt.Errorf("creating export file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	switch strings.ToLower(format) {
+	case "", "csv":
+		return bulk.ExportCSV(w, products)
+	case "json", "ndjson":
+		return bulk.ExportNDJSON(w, products)
+	default:
+		return fmt.Errorf("unknown export format %q (want csv or ndjson)", format)
+	}
+}
+
+func loadProductsFile(path string) ([]Product, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening import file: %w", err)
+	}
+	defer f.Close()
+
+	if strings.HasSuffix(strings.ToLower(path), ".json") {
+		return bulk.ParseJSON(f)
+	}
+	return bulk.ParseCSV(f)
+}
@@ -0,0 +1,377 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/amangirdhar210/inventoryClient/internal/output"
+)
+
+// runCLI dispatches a single non-interactive subcommand and returns the
+// process exit code. It is used when the binary is invoked with arguments,
+// e.g. `inventoryClient product list --output json`; with no arguments the
+// interactive menu in main() takes over instead.
+func runCLI(client *Client, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "missing subcommand")
+		return 1
+	}
+
+	var err error
+	switch args[0] {
+	case "login":
+		err = runLoginCmd(client, args[1:])
+	case "product":
+		err = runProductCmd(client, args[1:])
+	case "sell":
+		err = runSellCmd(client, args[1:])
+	case "restock":
+		err = runRestockCmd(client, args[1:])
+	case "price":
+		err = runPriceCmd(client, args[1:])
+	case "list":
+		err = runListCmd(client, args[1:])
+	case "inventory-value":
+		err = runInventoryValueCmd(client, args[1:])
+	case "import":
+		err = runImportCmd(client, args[1:])
+	case "export":
+		err = runExportCmd(client, args[1:])
+	default:
+		err = fmt.Errorf("unknown subcommand %q", args[0])
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// outputFlag attaches the shared --output flag to fs and returns the value
+// it parses into.
+func outputFlag(fs *flag.FlagSet) *string {
+	return fs.String("output", "table", "output format: table, json, or csv")
+}
+
+func runLoginCmd(client *Client, args []string) error {
+	fs := flag.NewFlagSet("login", flag.ContinueOnError)
+	email := fs.String("email", "", "account email")
+	password := fs.String("password", "", "account password")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *email == "" || *password == "" {
+		return fmt.Errorf("--email and --password are required")
+	}
+
+	body, statusCode, err := client.makeRequest("POST", "/login", map[string]string{"email": *email, "password": *password})
+	if err != nil {
+		return err
+	}
+	if statusCode != 200 {
+		return printErrorResponse(body)
+	}
+
+	var resp struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return err
+	}
+	client.setToken(resp.Token)
+	if err := client.SaveToken(); err != nil {
+		log.Printf("warning: could not persist session token: %v", err)
+	}
+	fmt.Println("login successful")
+	return nil
+}
+
+func runProductCmd(client *Client, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("product requires a subcommand: add, get, list, delete")
+	}
+
+	switch args[0] {
+	case "add":
+		return runProductAddCmd(client, args[1:])
+	case "get":
+		return runProductGetCmd(client, args[1:])
+	case "list":
+		return runListCmd(client, args[1:])
+	case "delete":
+		return runProductDeleteCmd(client, args[1:])
+	default:
+		return fmt.Errorf("unknown product subcommand %q", args[0])
+	}
+}
+
+func runProductAddCmd(client *Client, args []string) error {
+	fs := flag.NewFlagSet("product add", flag.ContinueOnError)
+	name := fs.String("name", "", "product name")
+	price := fs.Float64("price", 0, "product price")
+	quantity := fs.Int("quantity", 0, "product quantity")
+	format := outputFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *name == "" {
+		return fmt.Errorf("--name is required")
+	}
+
+	out, err := output.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{"name": *name, "price": *price, "quantity": *quantity}
+	body, statusCode, queued, err := client.dispatch("POST", "/api/products", payload)
+	if err != nil {
+		return err
+	}
+	if queued {
+		return output.WriteMessage(os.Stdout, out, "status", "queued: server unreachable, change recorded for sync")
+	}
+	if statusCode != 200 && statusCode != 201 {
+		return printErrorResponse(body)
+	}
+
+	var product Product
+	if err := json.Unmarshal(body, &product); err != nil {
+		return err
+	}
+	return output.WriteProducts(os.Stdout, out, []Product{product})
+}
+
+func runProductGetCmd(client *Client, args []string) error {
+	fs := flag.NewFlagSet("product get", flag.ContinueOnError)
+	id := fs.String("id", "", "product id")
+	format := outputFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *id == "" {
+		return fmt.Errorf("--id is required")
+	}
+
+	out, err := output.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+
+	body, statusCode, err := client.makeRequest("GET", "/api/products/"+*id, nil)
+	if err != nil {
+		return err
+	}
+	if statusCode != 200 {
+		return printErrorResponse(body)
+	}
+
+	var product Product
+	if err := json.Unmarshal(body, &product); err != nil {
+		return err
+	}
+	return output.WriteProducts(os.Stdout, out, []Product{product})
+}
+
+func runProductDeleteCmd(client *Client, args []string) error {
+	fs := flag.NewFlagSet("product delete", flag.ContinueOnError)
+	id := fs.String("id", "", "product id")
+	format := outputFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *id == "" {
+		return fmt.Errorf("--id is required")
+	}
+
+	out, err := output.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+
+	body, statusCode, queued, err := client.dispatch("DELETE", "/api/products/"+*id, nil)
+	if err != nil {
+		return err
+	}
+	if queued {
+		return output.WriteMessage(os.Stdout, out, "status", "queued: server unreachable, change recorded for sync")
+	}
+	if statusCode != 200 {
+		return printErrorResponse(body)
+	}
+	return output.WriteMessage(os.Stdout, out, "status", "product deleted successfully")
+}
+
+func runListCmd(client *Client, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	format := outputFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	out, err := output.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+
+	body, statusCode, err := client.makeRequest("GET", "/api/products", nil)
+	if err != nil {
+		return err
+	}
+	if statusCode != 200 {
+		return printErrorResponse(body)
+	}
+
+	var products []Product
+	if err := json.Unmarshal(body, &products); err != nil {
+		return err
+	}
+	return output.WriteProducts(os.Stdout, out, products)
+}
+
+func runSellCmd(client *Client, args []string) error {
+	fs := flag.NewFlagSet("sell", flag.ContinueOnError)
+	id := fs.String("id", "", "product id")
+	quantity := fs.Int("quantity", 0, "quantity to sell")
+	format := outputFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *id == "" {
+		return fmt.Errorf("--id is required")
+	}
+
+	out, err := output.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+
+	body, statusCode, queued, err := client.dispatch("PATCH", fmt.Sprintf("/api/products/%s/sell", *id), map[string]any{"quantity": *quantity})
+	if err != nil {
+		return err
+	}
+	if queued {
+		return output.WriteMessage(os.Stdout, out, "status", "queued: server unreachable, change recorded for sync")
+	}
+	if statusCode != 200 {
+		return printErrorResponse(body)
+	}
+	return output.WriteMessage(os.Stdout, out, "status", "sale processed successfully")
+}
+
+func runRestockCmd(client *Client, args []string) error {
+	fs := flag.NewFlagSet("restock", flag.ContinueOnError)
+	id := fs.String("id", "", "product id")
+	quantity := fs.Int("quantity", 0, "quantity to restock")
+	format := outputFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *id == "" {
+		return fmt.Errorf("--id is required")
+	}
+
+	out, err := output.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+
+	body, statusCode, queued, err := client.dispatch("PATCH", fmt.Sprintf("/api/products/%s/restock", *id), map[string]any{"quantity": *quantity})
+	if err != nil {
+		return err
+	}
+	if queued {
+		return output.WriteMessage(os.Stdout, out, "status", "queued: server unreachable, change recorded for sync")
+	}
+	if statusCode != 200 {
+		return printErrorResponse(body)
+	}
+	return output.WriteMessage(os.Stdout, out, "status", "product restocked successfully")
+}
+
+func runPriceCmd(client *Client, args []string) error {
+	fs := flag.NewFlagSet("price", flag.ContinueOnError)
+	id := fs.String("id", "", "product id")
+	value := fs.Float64("value", 0, "new price")
+	format := outputFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *id == "" {
+		return fmt.Errorf("--id is required")
+	}
+
+	out, err := output.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+
+	body, statusCode, queued, err := client.dispatch("PATCH", fmt.Sprintf("/api/products/%s/price", *id), map[string]any{"price": *value})
+	if err != nil {
+		return err
+	}
+	if queued {
+		return output.WriteMessage(os.Stdout, out, "status", "queued: server unreachable, change recorded for sync")
+	}
+	if statusCode != 200 {
+		return printErrorResponse(body)
+	}
+	return output.WriteMessage(os.Stdout, out, "status", "price updated successfully")
+}
+
+func runImportCmd(client *Client, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	file := fs.String("file", "", "path to a CSV or JSON file of products")
+	parallel := fs.Int("parallel", 8, "number of concurrent import workers")
+	dryRun := fs.Bool("dry-run", false, "validate rows without sending requests")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("--file is required")
+	}
+	return client.importProducts(*file, *parallel, *dryRun)
+}
+
+func runExportCmd(client *Client, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	file := fs.String("file", "", "destination path (omit for stdout)")
+	format := fs.String("format", "csv", "export format: csv or ndjson")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	return client.exportProducts(*file, *format)
+}
+
+func runInventoryValueCmd(client *Client, args []string) error {
+	fs := flag.NewFlagSet("inventory-value", flag.ContinueOnError)
+	format := outputFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	out, err := output.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+
+	body, statusCode, err := client.makeRequest("GET", "/api/inventory/value", nil)
+	if err != nil {
+		return err
+	}
+	if statusCode != 200 {
+		return printErrorResponse(body)
+	}
+
+	var result struct {
+		Value float64 `json:"inventory_value"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return err
+	}
+	return output.WriteMessage(os.Stdout, out, "inventory_value", fmt.Sprintf("%.2f", result.Value))
+}
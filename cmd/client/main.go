@@ -4,47 +4,112 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"text/tabwriter"
 	"time"
+
+	"github.com/amangirdhar210/inventoryClient/internal/alerts"
+	"github.com/amangirdhar210/inventoryClient/internal/auth"
+	"github.com/amangirdhar210/inventoryClient/internal/config"
+	"github.com/amangirdhar210/inventoryClient/internal/inventory"
+	"github.com/amangirdhar210/inventoryClient/internal/store"
+	"github.com/amangirdhar210/inventoryClient/internal/syncengine"
 )
 
 const serverBaseURL = "http://localhost:8080"
 
-type Product struct {
-	ID       string  `json:"id"`
-	Name     string  `json:"name"`
-	Price    float64 `json:"price"`
-	Quantity int     `json:"quantity"`
-}
+// Product is an alias for the shared inventory type, kept so the rest of
+// this file doesn't need to change while the package is gradually split up.
+type Product = inventory.Product
 
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
-	token      string
+
+	// tokenMu guards token: bulk operations (chunk0-3) dispatch requests
+	// from a worker pool, and any of those goroutines can trigger a
+	// refresh or a 401-triggered clear concurrently with the others.
+	tokenMu sync.RWMutex
+	token   string
+
+	cache   *store.Cache
+	journal *store.Journal
+
+	// etagsMu guards etags: the product ETags the server has handed back
+	// in responses so far this session, keyed by product ID, used to send
+	// If-Match on the next conditional mutation against that product.
+	etagsMu sync.Mutex
+	etags   map[string]string
+
+	alertsPath       string
+	alertsCfg        *alerts.Config
+	alertEvaluator   *alerts.Evaluator
+	alertPollStarted bool
 }
 
 func NewClient() *Client {
-	return &Client{
+	c := &Client{
 		httpClient: &http.Client{Timeout: 10 * time.Second},
 		baseURL:    serverBaseURL,
+		etags:      map[string]string{},
+	}
+
+	if cache, err := store.NewCache(); err == nil {
+		c.cache = cache
+	} else {
+		log.Printf("warning: offline cache unavailable: %v", err)
+	}
+	if journal, err := store.NewJournal(); err == nil {
+		c.journal = journal
+	} else {
+		log.Printf("warning: offline queue unavailable: %v", err)
 	}
+
+	c.loadAlerts()
+
+	return c
+}
+
+// getToken returns the current session token.
+func (c *Client) getToken() string {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.token
+}
+
+// setToken replaces the current session token.
+func (c *Client) setToken(token string) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	c.token = token
 }
 
 func main() {
 	client := NewClient()
+	client.LoadToken()
+	client.applyConfig()
+
+	if len(os.Args) > 1 {
+		os.Exit(runCLI(client, os.Args[1:]))
+	}
+
 	reader := bufio.NewReader(os.Stdin)
 
 	fmt.Println("--- Inventory Management API Client ---")
+	client.offerStartupSync(reader)
 
 	for {
-		if client.token == "" {
+		if client.getToken() == "" {
 			client.runLoggedOutLoop(reader)
 		} else {
 			client.runLoggedInLoop(reader)
@@ -52,6 +117,58 @@ func main() {
 	}
 }
 
+// applyConfig sources the base URL and token from the config file and then
+// from the environment, so the client is usable from shell scripts and CI
+// without the interactive login prompt. Environment variables take
+// precedence over the config file.
+func (c *Client) applyConfig() {
+	path, err := config.DefaultPath()
+	if err != nil {
+		return
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		log.Printf("warning: could not read config file: %v", err)
+		cfg = &config.Config{}
+	}
+
+	if cfg.BaseURL != "" {
+		c.baseURL = cfg.BaseURL
+	}
+	if cfg.Token != "" {
+		c.setToken(cfg.Token)
+	}
+
+	if v := os.Getenv("INVENTORY_CLIENT_BASE_URL"); v != "" {
+		c.baseURL = v
+	}
+	if v := os.Getenv("INVENTORY_CLIENT_TOKEN"); v != "" {
+		c.setToken(v)
+	}
+}
+
+// offerStartupSync checks for changes left over from a previous offline
+// session and, if any are found, asks the user whether to replay them now.
+func (c *Client) offerStartupSync(reader *bufio.Reader) {
+	if c.journal == nil {
+		return
+	}
+	ops, err := c.journal.Load()
+	if err != nil {
+		log.Printf("warning: could not read pending changes: %v", err)
+		return
+	}
+	if len(ops) == 0 {
+		return
+	}
+
+	fmt.Printf("\nYou have %d change(s) queued from a previous offline session.\n", len(ops))
+	choice := readString(reader, "Sync them now? (y/n): ")
+	if strings.EqualFold(choice, "y") {
+		c.syncPendingChanges()
+	}
+}
+
 func (c *Client) runLoggedOutLoop(reader *bufio.Reader) {
 	for {
 		fmt.Println("\n-------------------------------------")
@@ -81,6 +198,7 @@ func (c *Client) runLoggedOutLoop(reader *bufio.Reader) {
 func (c *Client) runLoggedInLoop(reader *bufio.Reader) {
 	fmt.Println("\n-------------------------------------")
 	fmt.Println("You are logged in.")
+	c.startAlertPolling()
 
 	for {
 		displayLoggedInMenu()
@@ -105,6 +223,16 @@ func (c *Client) runLoggedInLoop(reader *bufio.Reader) {
 		case "8":
 			err = c.getInventoryValue()
 		case "9":
+			err = c.syncPendingChanges()
+		case "10":
+			err = c.watchInventory(reader)
+		case "11":
+			err = c.importProductsInteractive(reader)
+		case "12":
+			err = c.exportProductsInteractive(reader)
+		case "13":
+			err = c.manageAlertThresholds(reader)
+		case "14":
 			c.logout()
 			return
 		default:
@@ -128,7 +256,12 @@ func displayLoggedInMenu() {
 	fmt.Println("6. Update Product Price")
 	fmt.Println("7. Delete Product")
 	fmt.Println("8. Get Total Inventory Value")
-	fmt.Println("9. Logout")
+	fmt.Println("9. Sync Pending Changes")
+	fmt.Println("10. Watch Inventory")
+	fmt.Println("11. Import Products")
+	fmt.Println("12. Export Products")
+	fmt.Println("13. View/Edit Low-Stock Alert Thresholds")
+	fmt.Println("14. Logout")
 }
 
 func (c *Client) login(reader *bufio.Reader) error {
@@ -153,16 +286,82 @@ func (c *Client) login(reader *bufio.Reader) error {
 		return fmt.Errorf("failed to decode login response: %w", err)
 	}
 
-	c.token = response.Token
+	c.setToken(response.Token)
+	if err := c.SaveToken(); err != nil {
+		log.Printf("warning: could not persist session token: %v", err)
+	}
 	return nil
 }
 
 func (c *Client) logout() {
-	c.token = ""
+	c.ClearToken()
 	fmt.Println("\nYou have been logged out.")
 }
 
+// refreshTokenIfNeeded proactively refreshes the session token when it's
+// within 60 seconds of expiring, so callers rarely hit a 401 from an
+// expired token in the first place.
+const tokenRefreshWindow = 60 * time.Second
+
+func (c *Client) refreshTokenIfNeeded() {
+	token := c.getToken()
+	if token == "" {
+		return
+	}
+	expiry, err := auth.Expiry(token)
+	if err != nil {
+		// Not a JWT we can introspect (or a test/opaque token); nothing to do.
+		return
+	}
+	if time.Until(expiry) > tokenRefreshWindow {
+		return
+	}
+
+	body, statusCode, err := c.makeRequestWithHeaders("POST", "/refresh", nil, nil)
+	if err != nil || statusCode != http.StatusOK {
+		log.Printf("warning: token refresh failed, continuing with existing token")
+		return
+	}
+	var resp struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil || resp.Token == "" {
+		return
+	}
+	c.setToken(resp.Token)
+	if err := c.SaveToken(); err != nil {
+		log.Printf("warning: could not persist refreshed token: %v", err)
+	}
+}
+
 func (c *Client) makeRequest(method, path string, payload any) ([]byte, int, error) {
+	return c.makeRequestWithHeaders(method, path, payload, nil)
+}
+
+// newRequest builds an HTTP request against the server with the client's
+// bearer token and any extra headers applied, without sending it. It's the
+// one place that knows how to turn a path into an authorized request, so
+// makeRequest and the inventory stream watcher stay in sync.
+func (c *Client) newRequest(method, path string, body io.Reader, headers map[string]string) (*http.Request, error) {
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if token := c.getToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return req, nil
+}
+
+func (c *Client) makeRequestWithHeaders(method, path string, payload any, headers map[string]string) ([]byte, int, error) {
+	if path != "/login" && path != "/refresh" {
+		c.refreshTokenIfNeeded()
+	}
+
 	var body io.Reader
 	if payload != nil {
 		jsonPayload, err := json.Marshal(payload)
@@ -172,19 +371,14 @@ func (c *Client) makeRequest(method, path string, payload any) ([]byte, int, err
 		body = bytes.NewBuffer(jsonPayload)
 	}
 
-	req, err := http.NewRequest(method, c.baseURL+path, body)
+	req, err := c.newRequest(method, path, body, headers)
 	if err != nil {
 		return nil, 0, err
 	}
-
 	if payload != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
-	}
-
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, 0, err
@@ -196,9 +390,211 @@ func (c *Client) makeRequest(method, path string, payload any) ([]byte, int, err
 		return nil, resp.StatusCode, err
 	}
 
+	if resp.StatusCode == http.StatusUnauthorized && path != "/login" {
+		c.ClearToken()
+	}
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		if id, ok := productIDFromPath(path); ok {
+			c.rememberETag(id, etag)
+		}
+	}
+
 	return respBody, resp.StatusCode, nil
 }
 
+// productIDFromPath extracts the product ID from a path of the form
+// /api/products/{id}[/...], reporting false for the collection endpoint or
+// anything else.
+func productIDFromPath(path string) (string, bool) {
+	const prefix = "/api/products/"
+	if !strings.HasPrefix(path, prefix) {
+		return "", false
+	}
+	id, _, _ := strings.Cut(strings.TrimPrefix(path, prefix), "/")
+	return id, id != ""
+}
+
+// rememberETag records the server-issued ETag for a product, so the next
+// conditional mutation against it can send it back as If-Match.
+func (c *Client) rememberETag(id, etag string) {
+	c.etagsMu.Lock()
+	defer c.etagsMu.Unlock()
+	c.etags[id] = etag
+}
+
+// isNetworkError reports whether err indicates the server could not be
+// reached at all (connection refused, DNS failure, timeout), as opposed to
+// the server responding with an error status.
+func isNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
+
+// dispatch sends a mutating request and, if the server is unreachable,
+// transparently queues it in the offline journal instead of surfacing the
+// error. queued reports which of those two things happened.
+func (c *Client) dispatch(method, path string, payload any) (body []byte, statusCode int, queued bool, err error) {
+	idempotencyKey := fmt.Sprintf("%d-%s-%s", time.Now().UnixNano(), method, path)
+	headers := map[string]string{"Idempotency-Key": idempotencyKey}
+	if etag, ok := c.cachedETag(path); ok {
+		headers["If-Match"] = etag
+	}
+
+	body, statusCode, err = c.makeRequestWithHeaders(method, path, payload, headers)
+	if err == nil || !isNetworkError(err) || c.journal == nil {
+		return body, statusCode, false, err
+	}
+
+	queueErr := c.journal.Append(store.PendingOperation{
+		IdempotencyKey: idempotencyKey,
+		Method:         method,
+		Path:           path,
+		Payload:        payload,
+		Headers:        headers,
+		Timestamp:      time.Now(),
+	})
+	if queueErr != nil {
+		return nil, 0, false, fmt.Errorf("server unreachable and could not queue change: %w", err)
+	}
+	c.applyQueuedOp(method, path, payload)
+	return nil, 0, true, nil
+}
+
+// applyQueuedOp updates the locally cached catalog to reflect a mutation
+// that was just queued for later replay, so the offline fallback in
+// fetchAllProducts doesn't keep showing pre-mutation quantities (and the
+// user doesn't oversell stock they believe is still available).
+func (c *Client) applyQueuedOp(method, path string, payload any) {
+	if c.cache == nil {
+		return
+	}
+	id, ok := productIDFromPath(path)
+	if !ok {
+		return
+	}
+
+	var cached []Product
+	ok, err := c.cache.Load(&cached)
+	if !ok || err != nil {
+		return
+	}
+	idx := -1
+	for i, p := range cached {
+		if p.ID == id {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return
+	}
+
+	switch {
+	case method == http.MethodDelete:
+		cached = append(cached[:idx], cached[idx+1:]...)
+	case strings.HasSuffix(path, "/sell"):
+		if qty, ok := intFromPayload(payload, "quantity"); ok {
+			cached[idx].Quantity -= qty
+		}
+	case strings.HasSuffix(path, "/restock"):
+		if qty, ok := intFromPayload(payload, "quantity"); ok {
+			cached[idx].Quantity += qty
+		}
+	case strings.HasSuffix(path, "/price"):
+		if price, ok := floatFromPayload(payload, "price"); ok {
+			cached[idx].Price = price
+		}
+	default:
+		return
+	}
+
+	if err := c.cache.Save(cached); err != nil {
+		log.Printf("warning: could not update offline cache: %v", err)
+	}
+}
+
+// intFromPayload and floatFromPayload pull a field back out of the
+// map[string]any payloads dispatch's callers build, used to apply a queued
+// mutation's effect to the cached catalog.
+func intFromPayload(payload any, key string) (int, bool) {
+	m, ok := payload.(map[string]any)
+	if !ok {
+		return 0, false
+	}
+	v, ok := m[key].(int)
+	return v, ok
+}
+
+func floatFromPayload(payload any, key string) (float64, bool) {
+	m, ok := payload.(map[string]any)
+	if !ok {
+		return 0, false
+	}
+	v, ok := m[key].(float64)
+	return v, ok
+}
+
+// cachedETag looks up the most recent server-issued ETag for the product
+// targeted by path (for paths of the form /api/products/{id}[/...]) and, if
+// one has been seen this session, returns the If-Match value a conditional
+// update against it should carry.
+func (c *Client) cachedETag(path string) (string, bool) {
+	id, ok := productIDFromPath(path)
+	if !ok {
+		return "", false
+	}
+
+	c.etagsMu.Lock()
+	defer c.etagsMu.Unlock()
+	etag, ok := c.etags[id]
+	return etag, ok
+}
+
+// syncPendingChanges replays the offline queue against the server in FIFO
+// order, printing a per-item outcome and pruning everything that was
+// applied or that the server rejected outright.
+func (c *Client) syncPendingChanges() error {
+	if c.journal == nil {
+		return fmt.Errorf("offline queue is unavailable")
+	}
+
+	fmt.Println("\n-> Syncing pending changes...")
+	ops, err := c.journal.Load()
+	if err != nil {
+		return err
+	}
+	if len(ops) == 0 {
+		fmt.Println("   Nothing to sync.")
+		return nil
+	}
+
+	results, applied := syncengine.Replay(ops, c.makeRequestWithHeaders)
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Printf("   FAILED  %s %s: %v\n", r.Operation.Method, r.Operation.Path, r.Err)
+		} else {
+			fmt.Printf("   OK      %s %s (status %d)\n", r.Operation.Method, r.Operation.Path, r.StatusCode)
+		}
+	}
+
+	if err := c.journal.Prune(applied); err != nil {
+		return fmt.Errorf("pruning synced journal: %w", err)
+	}
+	fmt.Printf("   Synced %d/%d pending change(s).\n", len(applied), len(ops))
+	return nil
+}
+
 func (c *Client) addProduct(reader *bufio.Reader) error {
 	fmt.Println("\n-> Adding a new Product...")
 	name := readString(reader, "   Enter Name: ")
@@ -206,10 +602,14 @@ func (c *Client) addProduct(reader *bufio.Reader) error {
 	quantity := readInt(reader, "   Enter Quantity: ")
 
 	payload := map[string]any{"name": name, "price": price, "quantity": quantity}
-	body, statusCode, err := c.makeRequest("POST", "/api/products", payload)
+	body, statusCode, queued, err := c.dispatch("POST", "/api/products", payload)
 	if err != nil {
 		return err
 	}
+	if queued {
+		fmt.Println("\n   Server unreachable; the new product has been queued and will be added once you sync.")
+		return nil
+	}
 
 	return handleProductResponse(body, statusCode, "Product added successfully.")
 }
@@ -226,21 +626,18 @@ func (c *Client) getProduct(reader *bufio.Reader) error {
 
 func (c *Client) listAllProducts() error {
 	fmt.Println("\n-> Listing All Products...")
-	body, statusCode, err := c.makeRequest("GET", "/api/products", nil)
+	products, fromCache, err := c.fetchAllProducts()
 	if err != nil {
 		return err
 	}
-
-	if statusCode != http.StatusOK {
-		return printErrorResponse(body)
-	}
-
-	var products []Product
-	if err := json.Unmarshal(body, &products); err != nil {
-		return err
+	if c.alertEvaluator != nil && !fromCache {
+		c.alertEvaluator.Evaluate(products)
 	}
 
 	fmt.Println("\n<- Server Response:")
+	if fromCache {
+		fmt.Println("(Server unreachable; showing locally cached catalog.)")
+	}
 	if len(products) == 0 {
 		fmt.Println("No products found in inventory.")
 	} else {
@@ -249,16 +646,58 @@ func (c *Client) listAllProducts() error {
 	return nil
 }
 
+// fetchAllProducts lists every product, falling back to the local cache
+// when the server is unreachable. It reports whether the cache was used.
+func (c *Client) fetchAllProducts() ([]Product, bool, error) {
+	body, statusCode, err := c.makeRequest("GET", "/api/products", nil)
+	if err != nil {
+		if isNetworkError(err) && c.cache != nil {
+			var cached []Product
+			if ok, cacheErr := c.cache.Load(&cached); ok && cacheErr == nil {
+				return cached, true, nil
+			}
+		}
+		return nil, false, err
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, false, printErrorResponse(body)
+	}
+
+	var products []Product
+	if err := json.Unmarshal(body, &products); err != nil {
+		return nil, false, err
+	}
+
+	if c.cache != nil {
+		if err := c.cache.Save(products); err != nil {
+			log.Printf("warning: could not update offline cache: %v", err)
+		}
+	}
+	return products, false, nil
+}
+
 func (c *Client) sellProduct(reader *bufio.Reader) error {
 	fmt.Println("\n-> Selling Product...")
 	id := readString(reader, "   Enter Product ID: ")
 	quantity := readInt(reader, "   Enter Quantity to Sell: ")
 
 	payload := map[string]any{"quantity": quantity}
-	body, statusCode, err := c.makeRequest("PATCH", fmt.Sprintf("/api/products/%s/sell", id), payload)
+	body, statusCode, queued, err := c.dispatch("PATCH", fmt.Sprintf("/api/products/%s/sell", id), payload)
 	if err != nil {
 		return err
 	}
+	if queued {
+		fmt.Println("\n   Server unreachable; the sale has been queued and will be applied once you sync.")
+		return nil
+	}
+
+	if c.alertEvaluator != nil && (statusCode == http.StatusOK || statusCode == http.StatusCreated) {
+		var product Product
+		if err := json.Unmarshal(body, &product); err == nil {
+			c.alertEvaluator.Evaluate([]Product{product})
+		}
+	}
 
 	return handleProductResponse(body, statusCode, "Sale processed successfully.")
 }
@@ -269,10 +708,14 @@ func (c *Client) restockProduct(reader *bufio.Reader) error {
 	quantity := readInt(reader, "   Enter Quantity to Restock: ")
 
 	payload := map[string]any{"quantity": quantity}
-	body, statusCode, err := c.makeRequest("PATCH", fmt.Sprintf("/api/products/%s/restock", id), payload)
+	body, statusCode, queued, err := c.dispatch("PATCH", fmt.Sprintf("/api/products/%s/restock", id), payload)
 	if err != nil {
 		return err
 	}
+	if queued {
+		fmt.Println("\n   Server unreachable; the restock has been queued and will be applied once you sync.")
+		return nil
+	}
 	return handleProductResponse(body, statusCode, "Product restocked successfully.")
 }
 
@@ -282,10 +725,14 @@ func (c *Client) updateProductPrice(reader *bufio.Reader) error {
 	price := readFloat(reader, "   Enter New Price: ")
 
 	payload := map[string]any{"price": price}
-	body, statusCode, err := c.makeRequest("PATCH", fmt.Sprintf("/api/products/%s/price", id), payload)
+	body, statusCode, queued, err := c.dispatch("PATCH", fmt.Sprintf("/api/products/%s/price", id), payload)
 	if err != nil {
 		return err
 	}
+	if queued {
+		fmt.Println("\n   Server unreachable; the price change has been queued and will be applied once you sync.")
+		return nil
+	}
 
 	return handleMessageResponse(body, statusCode, "Price updated successfully.")
 }
@@ -293,10 +740,14 @@ func (c *Client) updateProductPrice(reader *bufio.Reader) error {
 func (c *Client) deleteProduct(reader *bufio.Reader) error {
 	fmt.Println("\n-> Deleting a Product...")
 	id := readString(reader, "   Enter Product ID to Delete: ")
-	body, statusCode, err := c.makeRequest("DELETE", "/api/products/"+id, nil)
+	body, statusCode, queued, err := c.dispatch("DELETE", "/api/products/"+id, nil)
 	if err != nil {
 		return err
 	}
+	if queued {
+		fmt.Println("\n   Server unreachable; the deletion has been queued and will be applied once you sync.")
+		return nil
+	}
 	return handleMessageResponse(body, statusCode, "Product deleted successfully.")
 }
 
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/amangirdhar210/inventoryClient/internal/stream"
+)
+
+// watchInventory opens a live feed of inventory changes and renders them as
+// they arrive until the user presses Enter.
+func (c *Client) watchInventory(reader *bufio.Reader) error {
+	fmt.Println("\n-> Watching inventory for changes. Press Enter to stop.")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		reader.ReadString('\n')
+		cancel()
+	}()
+
+	watcher := stream.NewWatcher(c.sseDialer())
+	if dial := c.wsDialer(); dial != nil {
+		watcher.DialWS = dial
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tEVENT\tID\tNAME\tPRICE\tQUANTITY")
+	tw.Flush()
+
+	for evt := range watcher.Watch(ctx) {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t$%.2f\t%d\n",
+			time.Now().Format("15:04:05"), evt.Type, evt.Product.ID, evt.Product.Name, evt.Product.Price, evt.Product.Quantity)
+		tw.Flush()
+	}
+
+	fmt.Println("\n<- Stopped watching inventory.")
+	return nil
+}
+
+// sseDialer builds the SSE dial function for the stream watcher, reusing
+// the client's authorized request builder.
+func (c *Client) sseDialer() stream.SSEDialer {
+	return func(ctx context.Context) (*http.Response, error) {
+		req, err := c.newRequest(http.MethodGet, "/api/products/stream", nil, map[string]string{"Accept": "text/event-stream"})
+		if err != nil {
+			return nil, err
+		}
+		return c.httpClient.Do(req.WithContext(ctx))
+	}
+}
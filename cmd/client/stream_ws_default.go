@@ -0,0 +1,11 @@
+//go:build !websocket
+
+package main
+
+import "github.com/amangirdhar210/inventoryClient/internal/stream"
+
+// wsDialer reports that no WebSocket fallback is compiled in. Build with
+// `-tags websocket` to pull in the gorilla/websocket-backed implementation.
+func (c *Client) wsDialer() stream.WSDialer {
+	return nil
+}
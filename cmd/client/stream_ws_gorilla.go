@@ -0,0 +1,33 @@
+//go:build websocket
+
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/amangirdhar210/inventoryClient/internal/stream"
+)
+
+// wsDialer builds the WebSocket fallback dialer from the client's base URL
+// and current session token. Only compiled in with `-tags websocket`.
+func (c *Client) wsDialer() stream.WSDialer {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil
+	}
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	default:
+		u.Scheme = "ws"
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/api/products/stream"
+
+	headers := http.Header{}
+	if token := c.getToken(); token != "" {
+		headers.Set("Authorization", "Bearer "+token)
+	}
+	return stream.GorillaWSDialer(u.String(), headers)
+}
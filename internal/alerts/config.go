@@ -0,0 +1,196 @@
+// Package alerts evaluates product quantities against configured reorder
+// thresholds and dispatches low-stock notifications through a pluggable
+// set of notifiers.
+package alerts
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amangirdhar210/inventoryClient/internal/store"
+)
+
+// Config is the parsed contents of alerts.yaml. It supports the small
+// subset of YAML this package needs: flat `key: value` settings plus one
+// nested `thresholds:` block of `product-id: threshold` entries.
+type Config struct {
+	// DefaultThreshold applies to any product without a specific entry in
+	// Thresholds. Zero means "no default".
+	DefaultThreshold int
+	// PollInterval is how often the background checker re-evaluates the
+	// catalog. Zero disables background polling.
+	PollInterval time.Duration
+	// Thresholds maps product ID to reorder threshold. The key "*" is
+	// equivalent to DefaultThreshold and, if present, takes precedence.
+	Thresholds map[string]int
+
+	NotifyStdout  bool
+	NotifyWebhook string
+	NotifyDesktop bool
+
+	SMTPHost string
+	SMTPPort int
+	SMTPFrom string
+	SMTPTo   []string
+}
+
+// DefaultPath returns the client's default alerts config location.
+func DefaultPath() (string, error) {
+	dir, err := store.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "alerts.yaml"), nil
+}
+
+// LoadConfig reads a config file. A missing file yields a zero-value
+// Config (alerting disabled) rather than an error.
+func LoadConfig(path string) (*Config, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening alerts config: %w", err)
+	}
+	defer f.Close()
+
+	cfg := &Config{Thresholds: map[string]int{}}
+	inThresholds := false
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		raw := scanner.Text()
+		trimmed := strings.TrimSpace(raw)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		indented := strings.HasPrefix(raw, " ") || strings.HasPrefix(raw, "\t")
+		if !indented {
+			inThresholds = false
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			continue
+		}
+		key = unquote(strings.TrimSpace(key))
+		value = unquote(strings.TrimSpace(value))
+
+		if indented && inThresholds {
+			threshold, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid threshold for %q: %w", key, err)
+			}
+			cfg.Thresholds[key] = threshold
+			continue
+		}
+
+		switch key {
+		case "thresholds":
+			inThresholds = true
+		case "default_threshold":
+			cfg.DefaultThreshold, err = strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid default_threshold: %w", err)
+			}
+		case "poll_interval":
+			cfg.PollInterval, err = time.ParseDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid poll_interval: %w", err)
+			}
+		case "notify_stdout":
+			cfg.NotifyStdout = value == "true"
+		case "notify_webhook":
+			cfg.NotifyWebhook = value
+		case "notify_desktop":
+			cfg.NotifyDesktop = value == "true"
+		case "notify_smtp_host":
+			cfg.SMTPHost = value
+		case "notify_smtp_port":
+			cfg.SMTPPort, err = strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid notify_smtp_port: %w", err)
+			}
+		case "notify_smtp_from":
+			cfg.SMTPFrom = value
+		case "notify_smtp_to":
+			cfg.SMTPTo = splitAndTrim(value, ",")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading alerts config: %w", err)
+	}
+
+	if cfg.Thresholds["*"] != 0 {
+		cfg.DefaultThreshold = cfg.Thresholds["*"]
+	}
+	return cfg, nil
+}
+
+// Save writes cfg back to path, so the interactive threshold editor can
+// persist changes.
+func (cfg *Config) Save(path string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "default_threshold: %d\n", cfg.DefaultThreshold)
+	if cfg.PollInterval > 0 {
+		fmt.Fprintf(&b, "poll_interval: %s\n", cfg.PollInterval)
+	}
+	fmt.Fprintf(&b, "notify_stdout: %t\n", cfg.NotifyStdout)
+	if cfg.NotifyWebhook != "" {
+		fmt.Fprintf(&b, "notify_webhook: %s\n", cfg.NotifyWebhook)
+	}
+	fmt.Fprintf(&b, "notify_desktop: %t\n", cfg.NotifyDesktop)
+	if cfg.SMTPHost != "" {
+		fmt.Fprintf(&b, "notify_smtp_host: %s\n", cfg.SMTPHost)
+		fmt.Fprintf(&b, "notify_smtp_port: %d\n", cfg.SMTPPort)
+		fmt.Fprintf(&b, "notify_smtp_from: %s\n", cfg.SMTPFrom)
+		fmt.Fprintf(&b, "notify_smtp_to: %s\n", strings.Join(cfg.SMTPTo, ","))
+	}
+	if len(cfg.Thresholds) > 0 {
+		b.WriteString("thresholds:\n")
+		for id, threshold := range cfg.Thresholds {
+			fmt.Fprintf(&b, "  %s: %d\n", id, threshold)
+		}
+	}
+
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		return fmt.Errorf("writing alerts config: %w", err)
+	}
+	return nil
+}
+
+// Threshold returns the reorder threshold that applies to productID, and
+// whether one is configured at all.
+func (cfg *Config) Threshold(productID string) (int, bool) {
+	if t, ok := cfg.Thresholds[productID]; ok {
+		return t, true
+	}
+	if cfg.DefaultThreshold > 0 {
+		return cfg.DefaultThreshold, true
+	}
+	return 0, false
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func splitAndTrim(s, sep string) []string {
+	var out []string
+	for _, part := range strings.Split(s, sep) {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
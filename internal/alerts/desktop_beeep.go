@@ -0,0 +1,22 @@
+//go:build desktop
+
+package alerts
+
+import "github.com/gen2brain/beeep"
+
+func init() {
+	DefaultDesktopNotifier = beeepNotifier{}
+}
+
+// beeepNotifier backs Notifier with a native desktop notification via
+// github.com/gen2brain/beeep. Only compiled in with `-tags desktop`, since
+// that module isn't a dependency of the default build.
+type beeepNotifier struct{}
+
+func (beeepNotifier) Notify(a Alert) error {
+	title := "Low stock alert"
+	if a.Recovered {
+		title = "Stock recovered"
+	}
+	return beeep.Notify(title, a.message(), "")
+}
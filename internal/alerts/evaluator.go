@@ -0,0 +1,59 @@
+package alerts
+
+import (
+	"log"
+	"sync"
+
+	"github.com/amangirdhar210/inventoryClient/internal/inventory"
+)
+
+// Evaluator checks product quantities against the configured thresholds
+// and fans out alerts to its notifiers, de-duplicating so a product stuck
+// below threshold doesn't renotify on every check.
+type Evaluator struct {
+	cfg       *Config
+	notifiers []Notifier
+
+	mu    sync.Mutex
+	below map[string]bool // product ID -> currently notified as low-stock
+}
+
+// NewEvaluator builds an Evaluator for cfg, dispatching through notifiers.
+func NewEvaluator(cfg *Config, notifiers ...Notifier) *Evaluator {
+	return &Evaluator{cfg: cfg, notifiers: notifiers, below: map[string]bool{}}
+}
+
+// Evaluate checks every product against its threshold, firing a low-stock
+// alert the first time it drops at or below threshold and a recovery
+// alert the first time it rises back above.
+func (e *Evaluator) Evaluate(products []inventory.Product) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, p := range products {
+		threshold, ok := e.cfg.Threshold(p.ID)
+		if !ok {
+			continue
+		}
+
+		low := p.Quantity <= threshold
+		wasLow := e.below[p.ID]
+
+		switch {
+		case low && !wasLow:
+			e.below[p.ID] = true
+			e.dispatch(Alert{Product: p, Threshold: threshold})
+		case !low && wasLow:
+			e.below[p.ID] = false
+			e.dispatch(Alert{Product: p, Threshold: threshold, Recovered: true})
+		}
+	}
+}
+
+func (e *Evaluator) dispatch(a Alert) {
+	for _, n := range e.notifiers {
+		if err := n.Notify(a); err != nil {
+			log.Printf("warning: alert notifier failed: %v", err)
+		}
+	}
+}
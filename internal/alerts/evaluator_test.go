@@ -0,0 +1,79 @@
+package alerts
+
+import (
+	"testing"
+
+	"github.com/amangirdhar210/inventoryClient/internal/inventory"
+)
+
+type recordingNotifier struct {
+	alerts []Alert
+}
+
+func (n *recordingNotifier) Notify(a Alert) error {
+	n.alerts = append(n.alerts, a)
+	return nil
+}
+
+func TestEvaluatorFiresOnceWhileBelowThreshold(t *testing.T) {
+	cfg := &Config{Thresholds: map[string]int{"p1": 5}}
+	notifier := &recordingNotifier{}
+	e := NewEvaluator(cfg, notifier)
+
+	e.Evaluate([]inventory.Product{{ID: "p1", Quantity: 3}})
+	e.Evaluate([]inventory.Product{{ID: "p1", Quantity: 2}})
+	e.Evaluate([]inventory.Product{{ID: "p1", Quantity: 1}})
+
+	if len(notifier.alerts) != 1 {
+		t.Fatalf("got %d alerts, want 1 (no renotify while still below threshold)", len(notifier.alerts))
+	}
+	if notifier.alerts[0].Recovered {
+		t.Error("first alert should not be marked recovered")
+	}
+}
+
+func TestEvaluatorFiresRecoveryOnce(t *testing.T) {
+	cfg := &Config{Thresholds: map[string]int{"p1": 5}}
+	notifier := &recordingNotifier{}
+	e := NewEvaluator(cfg, notifier)
+
+	e.Evaluate([]inventory.Product{{ID: "p1", Quantity: 3}})
+	e.Evaluate([]inventory.Product{{ID: "p1", Quantity: 10}})
+	e.Evaluate([]inventory.Product{{ID: "p1", Quantity: 12}})
+
+	if len(notifier.alerts) != 2 {
+		t.Fatalf("got %d alerts, want 2 (low-stock, then one recovery)", len(notifier.alerts))
+	}
+	if notifier.alerts[1].Recovered != true {
+		t.Error("second alert should be marked recovered")
+	}
+}
+
+func TestEvaluatorSkipsProductsWithoutThreshold(t *testing.T) {
+	cfg := &Config{Thresholds: map[string]int{}}
+	notifier := &recordingNotifier{}
+	e := NewEvaluator(cfg, notifier)
+
+	e.Evaluate([]inventory.Product{{ID: "unconfigured", Quantity: 0}})
+
+	if len(notifier.alerts) != 0 {
+		t.Fatalf("got %d alerts, want 0 for a product with no configured threshold", len(notifier.alerts))
+	}
+}
+
+func TestEvaluatorRenotifiesAfterRecoveryAndRelapse(t *testing.T) {
+	cfg := &Config{Thresholds: map[string]int{"p1": 5}}
+	notifier := &recordingNotifier{}
+	e := NewEvaluator(cfg, notifier)
+
+	e.Evaluate([]inventory.Product{{ID: "p1", Quantity: 3}})  // low
+	e.Evaluate([]inventory.Product{{ID: "p1", Quantity: 10}}) // recovered
+	e.Evaluate([]inventory.Product{{ID: "p1", Quantity: 2}})  // low again
+
+	if len(notifier.alerts) != 3 {
+		t.Fatalf("got %d alerts, want 3 (low, recovered, low again)", len(notifier.alerts))
+	}
+	if notifier.alerts[2].Recovered {
+		t.Error("third alert should be a fresh low-stock notification, not recovered")
+	}
+}
@@ -0,0 +1,126 @@
+package alerts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/smtp"
+	"strings"
+
+	"github.com/amangirdhar210/inventoryClient/internal/inventory"
+)
+
+// Alert is a single low-stock (or recovered) notification.
+type Alert struct {
+	Product   inventory.Product
+	Threshold int
+	// Recovered is true when quantity has risen back above the threshold
+	// after a previous low-stock notification.
+	Recovered bool
+}
+
+func (a Alert) message() string {
+	if a.Recovered {
+		return fmt.Sprintf("%s (%s) is back above its reorder threshold of %d (now %d)", a.Product.Name, a.Product.ID, a.Threshold, a.Product.Quantity)
+	}
+	return fmt.Sprintf("%s (%s) is low on stock: %d left, reorder threshold is %d", a.Product.Name, a.Product.ID, a.Product.Quantity, a.Threshold)
+}
+
+// Notifier delivers an Alert somewhere.
+type Notifier interface {
+	Notify(a Alert) error
+}
+
+// StdoutNotifier prints alerts to an io.Writer, colored when it isn't
+// recovered (red) vs. recovered (green).
+type StdoutNotifier struct {
+	Out io.Writer
+}
+
+const (
+	ansiRed   = "\033[31m"
+	ansiGreen = "\033[32m"
+	ansiReset = "\033[0m"
+)
+
+func (n StdoutNotifier) Notify(a Alert) error {
+	color := ansiRed
+	if a.Recovered {
+		color = ansiGreen
+	}
+	_, err := fmt.Fprintf(n.Out, "%s[ALERT] %s%s\n", color, a.message(), ansiReset)
+	return err
+}
+
+// WebhookNotifier POSTs the alert as JSON to a user-supplied URL.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+func (n WebhookNotifier) Notify(a Alert) error {
+	client := n.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"product":   a.Product,
+		"threshold": a.Threshold,
+		"recovered": a.Recovered,
+		"message":   a.message(),
+	})
+	if err != nil {
+		return fmt.Errorf("encoding webhook payload: %w", err)
+	}
+
+	resp, err := client.Post(n.URL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("posting webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// EmailNotifier sends the alert over SMTP.
+type EmailNotifier struct {
+	Host string
+	Port int
+	From string
+	To   []string
+}
+
+func (n EmailNotifier) Notify(a Alert) error {
+	addr := fmt.Sprintf("%s:%d", n.Host, n.Port)
+	subject := "Low stock alert"
+	if a.Recovered {
+		subject = "Stock recovered"
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&body, "From: %s\r\n", n.From)
+	fmt.Fprintf(&body, "To: %s\r\n\r\n", strings.Join(n.To, ", "))
+	body.WriteString(a.message())
+
+	if err := smtp.SendMail(addr, nil, n.From, n.To, []byte(body.String())); err != nil {
+		return fmt.Errorf("sending alert email: %w", err)
+	}
+	return nil
+}
+
+// DefaultDesktopNotifier is used for desktop notifications. Building with
+// the "desktop" tag (and a desktop-notification dependency present) swaps
+// this for a real backend; see desktop_beeep.go.
+var DefaultDesktopNotifier Notifier = noopDesktopNotifier{}
+
+type noopDesktopNotifier struct{}
+
+func (noopDesktopNotifier) Notify(a Alert) error {
+	return fmt.Errorf("desktop notifications not available in this build")
+}
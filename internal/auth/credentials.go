@@ -0,0 +1,63 @@
+// Package auth persists the session token between runs and parses its
+// expiry so the client can refresh it before it lapses.
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/amangirdhar210/inventoryClient/internal/store"
+)
+
+// Credentials is the on-disk representation of the logged-in session.
+type Credentials struct {
+	Token string `json:"token"`
+}
+
+// Path returns the client's default credentials file location.
+func Path() (string, error) {
+	dir, err := store.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "credentials.json"), nil
+}
+
+// Load reads the saved credentials. A missing file is not an error; it
+// yields ok=false.
+func Load(path string) (creds Credentials, ok bool, err error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Credentials{}, false, nil
+	}
+	if err != nil {
+		return Credentials{}, false, fmt.Errorf("reading credentials: %w", err)
+	}
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return Credentials{}, false, fmt.Errorf("decoding credentials: %w", err)
+	}
+	return creds, creds.Token != "", nil
+}
+
+// Save writes creds to path with owner-only permissions.
+func Save(path string, creds Credentials) error {
+	data, err := json.Marshal(creds)
+	if err != nil {
+		return fmt.Errorf("encoding credentials: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("writing credentials: %w", err)
+	}
+	return nil
+}
+
+// Clear removes the saved credentials file, if any.
+func Clear(path string) error {
+	err := os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing credentials: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func encodeSegment(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func TestExpiry(t *testing.T) {
+	exp := time.Unix(1_800_000_000, 0)
+	token := "header." + encodeSegment(map[string]int64{"exp": exp.Unix()}) + ".signature"
+
+	got, err := Expiry(token)
+	if err != nil {
+		t.Fatalf("Expiry returned error: %v", err)
+	}
+	if !got.Equal(exp) {
+		t.Errorf("Expiry = %v, want %v", got, exp)
+	}
+}
+
+func TestExpiryRejectsMalformedToken(t *testing.T) {
+	cases := map[string]string{
+		"not three segments":  "onlyonesegment",
+		"invalid base64":      "header.not!base64url.signature",
+		"invalid claims JSON": "header." + base64.RawURLEncoding.EncodeToString([]byte("not json")) + ".signature",
+		"missing exp claim":   "header." + encodeSegment(map[string]string{"sub": "user"}) + ".signature",
+	}
+
+	for name, token := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Expiry(token); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
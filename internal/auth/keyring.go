@@ -0,0 +1,28 @@
+package auth
+
+import "errors"
+
+// ErrKeyringUnavailable is returned by the default Keyring when no OS
+// keyring backend was compiled in.
+var ErrKeyringUnavailable = errors.New("os keyring not available in this build")
+
+// Keyring stores a single secret in the OS credential store. Token
+// persistence always falls back to the on-disk credentials file when a
+// Keyring returns ErrKeyringUnavailable (or any other error).
+type Keyring interface {
+	Get(service, user string) (string, error)
+	Set(service, user, secret string) error
+	Delete(service, user string) error
+}
+
+// DefaultKeyring is used by the client to mirror the token into the OS
+// keyring on platforms where one is available. Building with the
+// "keyring" build tag (and the github.com/zalando/go-keyring dependency
+// present) swaps this for a real backend; see keyring_zalando.go.
+var DefaultKeyring Keyring = noopKeyring{}
+
+type noopKeyring struct{}
+
+func (noopKeyring) Get(service, user string) (string, error) { return "", ErrKeyringUnavailable }
+func (noopKeyring) Set(service, user, secret string) error   { return ErrKeyringUnavailable }
+func (noopKeyring) Delete(service, user string) error        { return ErrKeyringUnavailable }
@@ -0,0 +1,26 @@
+//go:build keyring
+
+package auth
+
+import "github.com/zalando/go-keyring"
+
+func init() {
+	DefaultKeyring = zalandoKeyring{}
+}
+
+// zalandoKeyring backs Keyring with the OS credential store via
+// github.com/zalando/go-keyring. Only compiled in with `-tags keyring`,
+// since that module isn't a dependency of the default build.
+type zalandoKeyring struct{}
+
+func (zalandoKeyring) Get(service, user string) (string, error) {
+	return keyring.Get(service, user)
+}
+
+func (zalandoKeyring) Set(service, user, secret string) error {
+	return keyring.Set(service, user, secret)
+}
+
+func (zalandoKeyring) Delete(service, user string) error {
+	return keyring.Delete(service, user)
+}
@@ -0,0 +1,268 @@
+// Package bulk implements concurrent bulk import and export of products,
+// importing from CSV or a JSON array and exporting to CSV or
+// newline-delimited JSON, for both the CLI and the interactive menu.
+package bulk
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amangirdhar210/inventoryClient/internal/inventory"
+)
+
+// RequestFunc performs a single HTTP request, matching the client's
+// makeRequest method so bulk operations don't need to know about the
+// client's transport details.
+type RequestFunc func(method, path string, payload any) ([]byte, int, error)
+
+// ImportOptions configures a bulk import run.
+type ImportOptions struct {
+	// Parallel is the number of concurrent workers issuing requests.
+	Parallel int
+	// DryRun validates rows without sending any request.
+	DryRun bool
+	// MaxRetries is the number of retry attempts after a transport error or
+	// a 5xx/429 response. Zero or less means 3.
+	MaxRetries int
+}
+
+// RejectedRow is a product that could not be imported, paired with why.
+type RejectedRow struct {
+	Row     int
+	Product inventory.Product
+	Reason  string
+}
+
+// ImportResult summarizes the outcome of an import run.
+type ImportResult struct {
+	Succeeded int
+	Failed    int
+	Rejects   []RejectedRow
+}
+
+// ImportProducts sends a POST /api/products request per product, using a
+// worker pool of opts.Parallel goroutines. Rows that fail validation, get a
+// non-2xx response, or still fail once retries are exhausted are collected
+// in the result's Rejects rather than aborting the run.
+func ImportProducts(products []inventory.Product, do RequestFunc, opts ImportOptions) ImportResult {
+	if opts.Parallel <= 0 {
+		opts.Parallel = 8
+	}
+
+	type job struct {
+		row     int
+		product inventory.Product
+	}
+	jobs := make(chan job)
+	rejects := make(chan RejectedRow, len(products))
+	var succeeded, failed int32
+	var mu sync.Mutex
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				if err := validateProduct(j.product); err != nil {
+					rejects <- RejectedRow{Row: j.row, Product: j.product, Reason: err.Error()}
+					mu.Lock()
+					failed++
+					mu.Unlock()
+					continue
+				}
+
+				if opts.DryRun {
+					mu.Lock()
+					succeeded++
+					mu.Unlock()
+					continue
+				}
+
+				payload := map[string]any{"name": j.product.Name, "price": j.product.Price, "quantity": j.product.Quantity}
+				_, statusCode, err := withRetry(opts.MaxRetries, func() ([]byte, int, error) {
+					return do("POST", "/api/products", payload)
+				})
+
+				mu.Lock()
+				if err != nil || (statusCode != 200 && statusCode != 201) {
+					reason := fmt.Sprintf("status %d", statusCode)
+					if err != nil {
+						reason = err.Error()
+					}
+					rejects <- RejectedRow{Row: j.row, Product: j.product, Reason: reason}
+					failed++
+				} else {
+					succeeded++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	go func() {
+		for i, p := range products {
+			jobs <- job{row: i + 1, product: p}
+		}
+		close(jobs)
+	}()
+
+	wg.Wait()
+	close(rejects)
+
+	result := ImportResult{Succeeded: int(succeeded), Failed: int(failed)}
+	for r := range rejects {
+		result.Rejects = append(result.Rejects, r)
+	}
+	return result
+}
+
+func validateProduct(p inventory.Product) error {
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if p.Price < 0 {
+		return fmt.Errorf("price cannot be negative")
+	}
+	if p.Quantity < 0 {
+		return fmt.Errorf("quantity cannot be negative")
+	}
+	return nil
+}
+
+// withRetry retries fn with exponential backoff when it fails outright or
+// returns a 5xx/429 status, up to maxRetries additional attempts.
+func withRetry(maxRetries int, fn func() ([]byte, int, error)) ([]byte, int, error) {
+	if maxRetries <= 0 {
+		maxRetries = 3
+	}
+
+	var body []byte
+	var statusCode int
+	var err error
+	backoff := 200 * time.Millisecond
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		body, statusCode, err = fn()
+		retryable := err != nil || statusCode >= 500 || statusCode == 429
+		if !retryable {
+			return body, statusCode, err
+		}
+		if attempt == maxRetries {
+			break
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+	return body, statusCode, err
+}
+
+// WriteRejects writes the rejected rows as CSV to w.
+func WriteRejects(w io.Writer, rejects []RejectedRow) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"row", "name", "price", "quantity", "reason"}); err != nil {
+		return err
+	}
+	for _, r := range rejects {
+		row := []string{
+			strconv.Itoa(r.Row),
+			r.Product.Name,
+			strconv.FormatFloat(r.Product.Price, 'f', 2, 64),
+			strconv.Itoa(r.Product.Quantity),
+			r.Reason,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ParseCSV reads products from a CSV file with a header row of
+// name,price,quantity (id is ignored/assigned by the server).
+func ParseCSV(r io.Reader) ([]inventory.Product, error) {
+	cr := csv.NewReader(r)
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	col := make(map[string]int, len(header))
+	for i, name := range header {
+		col[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	products := make([]inventory.Product, 0, len(records)-1)
+	for _, record := range records[1:] {
+		p := inventory.Product{}
+		if i, ok := col["name"]; ok && i < len(record) {
+			p.Name = record[i]
+		}
+		if i, ok := col["price"]; ok && i < len(record) {
+			price, err := strconv.ParseFloat(record[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid price %q: %w", record[i], err)
+			}
+			p.Price = price
+		}
+		if i, ok := col["quantity"]; ok && i < len(record) {
+			qty, err := strconv.Atoi(record[i])
+			if err != nil {
+				return nil, fmt.Errorf("invalid quantity %q: %w", record[i], err)
+			}
+			p.Quantity = qty
+		}
+		products = append(products, p)
+	}
+	return products, nil
+}
+
+// ParseJSON reads products from a JSON array.
+func ParseJSON(r io.Reader) ([]inventory.Product, error) {
+	var products []inventory.Product
+	if err := json.NewDecoder(r).Decode(&products); err != nil {
+		return nil, fmt.Errorf("reading JSON: %w", err)
+	}
+	return products, nil
+}
+
+// ExportCSV streams products as CSV to w.
+func ExportCSV(w io.Writer, products []inventory.Product) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"id", "name", "price", "quantity"}); err != nil {
+		return err
+	}
+	for _, p := range products {
+		row := []string{p.ID, p.Name, strconv.FormatFloat(p.Price, 'f', 2, 64), strconv.Itoa(p.Quantity)}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ExportNDJSON streams products as newline-delimited JSON to w, one object
+// per line.
+func ExportNDJSON(w io.Writer, products []inventory.Product) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for _, p := range products {
+		if err := enc.Encode(p); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,100 @@
+package bulk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/amangirdhar210/inventoryClient/internal/inventory"
+)
+
+func TestParseCSV(t *testing.T) {
+	input := "name,price,quantity\nWidget,9.99,10\nGadget,19.5,3\n"
+
+	products, err := ParseCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseCSV returned error: %v", err)
+	}
+
+	want := []inventory.Product{
+		{Name: "Widget", Price: 9.99, Quantity: 10},
+		{Name: "Gadget", Price: 19.5, Quantity: 3},
+	}
+	if len(products) != len(want) {
+		t.Fatalf("got %d products, want %d", len(products), len(want))
+	}
+	for i, p := range products {
+		if p != want[i] {
+			t.Errorf("row %d: got %+v, want %+v", i, p, want[i])
+		}
+	}
+}
+
+func TestParseCSVInvalidPrice(t *testing.T) {
+	input := "name,price,quantity\nWidget,not-a-number,10\n"
+
+	if _, err := ParseCSV(strings.NewReader(input)); err == nil {
+		t.Fatal("expected an error for an invalid price column")
+	}
+}
+
+func TestImportProductsAccounting(t *testing.T) {
+	products := []inventory.Product{
+		{Name: "Widget", Price: 9.99, Quantity: 10},
+		{Name: "", Price: 1, Quantity: 1},       // fails validation
+		{Name: "Gizmo", Price: -1, Quantity: 1}, // fails validation
+		{Name: "Gadget", Price: 19.5, Quantity: 3},
+	}
+
+	do := func(method, path string, payload any) ([]byte, int, error) {
+		return nil, 201, nil
+	}
+
+	result := ImportProducts(products, do, ImportOptions{Parallel: 2})
+
+	if result.Succeeded != 2 {
+		t.Errorf("Succeeded = %d, want 2", result.Succeeded)
+	}
+	if result.Failed != 2 {
+		t.Errorf("Failed = %d, want 2", result.Failed)
+	}
+	if len(result.Rejects) != 2 {
+		t.Fatalf("len(Rejects) = %d, want 2", len(result.Rejects))
+	}
+}
+
+func TestImportProductsRetriesRetryableFailures(t *testing.T) {
+	products := []inventory.Product{{Name: "Widget", Price: 9.99, Quantity: 10}}
+
+	var attempts int
+	do := func(method, path string, payload any) ([]byte, int, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, 503, nil
+		}
+		return nil, 201, nil
+	}
+
+	result := ImportProducts(products, do, ImportOptions{Parallel: 1, MaxRetries: 3})
+
+	if result.Succeeded != 1 || result.Failed != 0 {
+		t.Fatalf("got succeeded=%d failed=%d, want succeeded=1 failed=0", result.Succeeded, result.Failed)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestImportProductsDryRunSkipsRequests(t *testing.T) {
+	products := []inventory.Product{{Name: "Widget", Price: 9.99, Quantity: 10}}
+
+	do := func(method, path string, payload any) ([]byte, int, error) {
+		t.Fatal("dry run should not issue requests")
+		return nil, 0, nil
+	}
+
+	result := ImportProducts(products, do, ImportOptions{Parallel: 1, DryRun: true})
+
+	if result.Succeeded != 1 || result.Failed != 0 {
+		t.Fatalf("got succeeded=%d failed=%d, want succeeded=1 failed=0", result.Succeeded, result.Failed)
+	}
+}
@@ -0,0 +1,67 @@
+// Package config reads the client's TOML config file, so credentials and
+// connection settings can come from disk instead of interactive prompts.
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/amangirdhar210/inventoryClient/internal/store"
+)
+
+// Config holds the settings that can be sourced from ~/.inventory-client/config.toml.
+type Config struct {
+	BaseURL string
+	Token   string
+}
+
+// DefaultPath returns the client's default config file location.
+func DefaultPath() (string, error) {
+	dir, err := store.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.toml"), nil
+}
+
+// Load reads a flat `key = "value"` TOML file. Missing files are not an
+// error; they simply yield a zero-value Config.
+func Load(path string) (*Config, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening config: %w", err)
+	}
+	defer f.Close()
+
+	cfg := &Config{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+
+		switch key {
+		case "base_url":
+			cfg.BaseURL = value
+		case "token":
+			cfg.Token = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+	return cfg, nil
+}
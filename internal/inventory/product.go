@@ -0,0 +1,12 @@
+// Package inventory holds the data types shared between the interactive
+// client, its CLI subcommands, and the offline/sync machinery.
+package inventory
+
+// Product mirrors the JSON representation of a product returned by the
+// inventory API.
+type Product struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Price    float64 `json:"price"`
+	Quantity int     `json:"quantity"`
+}
@@ -0,0 +1,88 @@
+// Package output renders client results as a table, JSON, or CSV, so the
+// same rendering logic can back both the interactive menu and the
+// scriptable CLI subcommands.
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/amangirdhar210/inventoryClient/internal/inventory"
+)
+
+// Format selects how results are rendered.
+type Format string
+
+const (
+	Table Format = "table"
+	JSON  Format = "json"
+	CSV   Format = "csv"
+)
+
+// ParseFormat validates a user-supplied --output value.
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case Table, JSON, CSV:
+		return Format(s), nil
+	default:
+		return "", fmt.Errorf("unknown output format %q (want table, json, or csv)", s)
+	}
+}
+
+// WriteProducts renders products to w in the requested format.
+func WriteProducts(w io.Writer, format Format, products []inventory.Product) error {
+	switch format {
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(products)
+	case CSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write([]string{"id", "name", "price", "quantity"}); err != nil {
+			return err
+		}
+		for _, p := range products {
+			row := []string{p.ID, p.Name, strconv.FormatFloat(p.Price, 'f', 2, 64), strconv.Itoa(p.Quantity)}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(tw, "ID\tNAME\tPRICE\tQUANTITY")
+		fmt.Fprintln(tw, "--\t----\t-----\t--------")
+		for _, p := range products {
+			fmt.Fprintf(tw, "%s\t%s\t$%.2f\t%d\n", p.ID, p.Name, p.Price, p.Quantity)
+		}
+		return tw.Flush()
+	}
+}
+
+// WriteMessage renders a one-line status/result message in the requested
+// format.
+func WriteMessage(w io.Writer, format Format, key, message string) error {
+	switch format {
+	case JSON:
+		enc := json.NewEncoder(w)
+		return enc.Encode(map[string]string{key: message})
+	case CSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write([]string{key}); err != nil {
+			return err
+		}
+		if err := cw.Write([]string{message}); err != nil {
+			return err
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		_, err := fmt.Fprintln(w, message)
+		return err
+	}
+}
@@ -0,0 +1,178 @@
+// Package store persists the local product catalog cache and the queue of
+// mutations that could not be sent to the server while offline.
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// PendingOperation is a single queued mutation awaiting replay against the
+// server, recorded in FIFO order.
+type PendingOperation struct {
+	IdempotencyKey string            `json:"idempotency_key"`
+	Method         string            `json:"method"`
+	Path           string            `json:"path"`
+	Payload        any               `json:"payload,omitempty"`
+	Headers        map[string]string `json:"headers,omitempty"`
+	Timestamp      time.Time         `json:"timestamp"`
+}
+
+// Dir returns the client's local state directory, creating it if necessary.
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving home directory: %w", err)
+	}
+	dir := filepath.Join(home, ".inventory-client")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("creating state directory: %w", err)
+	}
+	return dir, nil
+}
+
+// Journal is an append-only, newline-delimited JSON log of pending
+// operations at a fixed path on disk.
+type Journal struct {
+	path string
+}
+
+// NewJournal opens the journal at the client's default location
+// (~/.inventory-client/pending.jsonl).
+func NewJournal() (*Journal, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	return &Journal{path: filepath.Join(dir, "pending.jsonl")}, nil
+}
+
+// Append records a new pending operation at the end of the journal.
+func (j *Journal) Append(op PendingOperation) error {
+	f, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening journal: %w", err)
+	}
+	defer f.Close()
+
+	line, err := json.Marshal(op)
+	if err != nil {
+		return fmt.Errorf("encoding pending operation: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("writing to journal: %w", err)
+	}
+	return nil
+}
+
+// Load returns all pending operations in the order they were queued.
+func (j *Journal) Load() ([]PendingOperation, error) {
+	f, err := os.Open(j.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening journal: %w", err)
+	}
+	defer f.Close()
+
+	var ops []PendingOperation
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var op PendingOperation
+		if err := json.Unmarshal(line, &op); err != nil {
+			return nil, fmt.Errorf("decoding pending operation: %w", err)
+		}
+		ops = append(ops, op)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading journal: %w", err)
+	}
+	return ops, nil
+}
+
+// Prune rewrites the journal keeping only operations whose idempotency key
+// is not in applied.
+func (j *Journal) Prune(applied map[string]bool) error {
+	ops, err := j.Load()
+	if err != nil {
+		return err
+	}
+
+	tmp := j.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("creating journal: %w", err)
+	}
+
+	for _, op := range ops {
+		if applied[op.IdempotencyKey] {
+			continue
+		}
+		line, err := json.Marshal(op)
+		if err != nil {
+			f.Close()
+			return fmt.Errorf("encoding pending operation: %w", err)
+		}
+		if _, err := f.Write(append(line, '\n')); err != nil {
+			f.Close()
+			return fmt.Errorf("writing journal: %w", err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing journal: %w", err)
+	}
+	return os.Rename(tmp, j.path)
+}
+
+// Cache is the locally cached view of the product catalog, used to keep the
+// client usable while offline.
+type Cache struct {
+	path string
+}
+
+// NewCache opens the catalog cache at the client's default location
+// (~/.inventory-client/catalog.json).
+func NewCache() (*Cache, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{path: filepath.Join(dir, "catalog.json")}, nil
+}
+
+// Save overwrites the cached catalog with products.
+func (c *Cache) Save(products any) error {
+	data, err := json.MarshalIndent(products, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding catalog cache: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0o600); err != nil {
+		return fmt.Errorf("writing catalog cache: %w", err)
+	}
+	return nil
+}
+
+// Load decodes the cached catalog into out. It returns false, nil if no
+// cache has been saved yet.
+func (c *Cache) Load(out any) (bool, error) {
+	data, err := os.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("reading catalog cache: %w", err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return false, fmt.Errorf("decoding catalog cache: %w", err)
+	}
+	return true, nil
+}
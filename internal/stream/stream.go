@@ -0,0 +1,187 @@
+// Package stream consumes the server's live inventory event feed, using
+// Server-Sent Events as the primary transport and falling back to
+// WebSocket when the server (or a proxy in front of it) doesn't support
+// SSE, reconnecting with jittered backoff on any disconnect.
+package stream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/amangirdhar210/inventoryClient/internal/inventory"
+)
+
+// Event is a single inventory change pushed by the server.
+type Event struct {
+	Type    string            `json:"type"`
+	Product inventory.Product `json:"product"`
+}
+
+// ErrSSEUnsupported is returned when the server's response to the stream
+// request isn't actually an SSE stream, signalling the caller to fall back
+// to WebSocket.
+var ErrSSEUnsupported = errors.New("server response is not an SSE stream")
+
+// ErrWebSocketUnavailable is returned by the default WSDialer when no
+// WebSocket backend was compiled in.
+var ErrWebSocketUnavailable = errors.New("websocket fallback not available in this build")
+
+// SSEDialer opens the SSE connection, with auth/URL already applied.
+type SSEDialer func(ctx context.Context) (*http.Response, error)
+
+// WSDialer opens the WebSocket fallback connection and returns a channel
+// of decoded events.
+type WSDialer func(ctx context.Context) (<-chan Event, error)
+
+// DefaultWSDialer is used when a Watcher isn't given one explicitly. It
+// always returns ErrWebSocketUnavailable; a real backend (GorillaWSDialer,
+// built with the "websocket" tag) has to be set on Watcher.DialWS.
+var DefaultWSDialer WSDialer = func(ctx context.Context) (<-chan Event, error) {
+	return nil, ErrWebSocketUnavailable
+}
+
+// Watcher reconnects to the inventory event feed for as long as its
+// context stays alive.
+type Watcher struct {
+	DialSSE    SSEDialer
+	DialWS     WSDialer
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// NewWatcher builds a Watcher that dials SSE via dial and falls back to
+// DefaultWSDialer.
+func NewWatcher(dial SSEDialer) *Watcher {
+	return &Watcher{
+		DialSSE:    dial,
+		DialWS:     DefaultWSDialer,
+		MinBackoff: time.Second,
+		MaxBackoff: 30 * time.Second,
+	}
+}
+
+// Watch starts the reconnect loop in a goroutine and returns the channel
+// events are delivered on. The channel is closed once ctx is cancelled.
+func (w *Watcher) Watch(ctx context.Context) <-chan Event {
+	events := make(chan Event)
+	go w.run(ctx, events)
+	return events
+}
+
+func (w *Watcher) run(ctx context.Context, events chan<- Event) {
+	defer close(events)
+	backoff := w.MinBackoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+
+	for ctx.Err() == nil {
+		err := w.connectOnce(ctx, events)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			// Swallowed here: a disconnect or unreachable server just means
+			// "try again after a backoff", not a fatal condition.
+			_ = err
+		}
+
+		select {
+		case <-time.After(jitter(backoff)):
+		case <-ctx.Done():
+			return
+		}
+
+		backoff *= 2
+		if max := w.MaxBackoff; max > 0 && backoff > max {
+			backoff = max
+		}
+	}
+}
+
+// connectOnce makes a single connection attempt, trying SSE first and
+// falling back to WebSocket if the SSE request fails or the server doesn't
+// support SSE.
+func (w *Watcher) connectOnce(ctx context.Context, events chan<- Event) error {
+	resp, err := w.DialSSE(ctx)
+	if err == nil {
+		sseErr := readSSE(ctx, resp, events)
+		if sseErr != ErrSSEUnsupported {
+			return sseErr
+		}
+		err = sseErr
+	}
+
+	dialWS := w.DialWS
+	if dialWS == nil {
+		dialWS = DefaultWSDialer
+	}
+	wsEvents, wsErr := dialWS(ctx)
+	if wsErr != nil {
+		return fmt.Errorf("sse failed (%v) and websocket fallback failed (%w)", err, wsErr)
+	}
+	for evt := range wsEvents {
+		select {
+		case events <- evt:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return fmt.Errorf("websocket stream closed")
+}
+
+func readSSE(ctx context.Context, resp *http.Response, events chan<- Event) error {
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		return ErrSSEUnsupported
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "" {
+			continue
+		}
+
+		var evt Event
+		if err := json.Unmarshal([]byte(data), &evt); err != nil {
+			continue
+		}
+
+		select {
+		case events <- evt:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("sse stream closed by server")
+}
+
+// jitter returns a duration in [d/2, 3d/2) so reconnecting clients don't
+// all retry in lockstep.
+func jitter(d time.Duration) time.Duration {
+	half := int64(d) / 2
+	if half <= 0 {
+		return d
+	}
+	return time.Duration(half) + time.Duration(rand.Int63n(half*2))
+}
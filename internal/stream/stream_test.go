@@ -0,0 +1,204 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/amangirdhar210/inventoryClient/internal/inventory"
+)
+
+func sseResponse(statusCode int, contentType, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": []string{contentType}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestReadSSEDecodesEvents(t *testing.T) {
+	body := "data: {\"type\":\"created\",\"product\":{\"id\":\"1\",\"name\":\"Widget\"}}\n" +
+		"not-a-data-line\n" +
+		"data: not-json\n" +
+		"data: {\"type\":\"deleted\",\"product\":{\"id\":\"2\"}}\n"
+	resp := sseResponse(http.StatusOK, "text/event-stream", body)
+
+	events := make(chan Event, 2)
+	err := readSSE(context.Background(), resp, events)
+	close(events)
+
+	if err == nil {
+		t.Fatal("expected an error once the stream body is exhausted")
+	}
+
+	var got []Event
+	for evt := range events {
+		got = append(got, evt)
+	}
+	want := []Event{
+		{Type: "created", Product: inventory.Product{ID: "1", Name: "Widget"}},
+		{Type: "deleted", Product: inventory.Product{ID: "2"}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(got), len(want), got)
+	}
+	for i, evt := range got {
+		if evt != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, evt, want[i])
+		}
+	}
+}
+
+func TestReadSSERejectsNonSSEResponse(t *testing.T) {
+	cases := []struct {
+		name string
+		resp *http.Response
+	}{
+		{"wrong status", sseResponse(http.StatusNotFound, "text/event-stream", "")},
+		{"wrong content type", sseResponse(http.StatusOK, "application/json", "")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := readSSE(context.Background(), tc.resp, make(chan Event))
+			if !errors.Is(err, ErrSSEUnsupported) {
+				t.Fatalf("got %v, want ErrSSEUnsupported", err)
+			}
+		})
+	}
+}
+
+func TestReadSSEStopsOnContextCancellation(t *testing.T) {
+	resp := sseResponse(http.StatusOK, "text/event-stream", "data: {\"type\":\"created\"}\n")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := readSSE(ctx, resp, make(chan Event))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want context.Canceled", err)
+	}
+}
+
+func TestJitterStaysWithinBounds(t *testing.T) {
+	d := 100 * time.Millisecond
+	for i := 0; i < 50; i++ {
+		got := jitter(d)
+		if got < d/2 || got >= d+d/2 {
+			t.Fatalf("jitter(%v) = %v, want within [%v, %v)", d, got, d/2, d+d/2)
+		}
+	}
+}
+
+func TestJitterHandlesNonPositiveDuration(t *testing.T) {
+	if got := jitter(0); got != 0 {
+		t.Errorf("jitter(0) = %v, want 0", got)
+	}
+	if got := jitter(-time.Second); got != -time.Second {
+		t.Errorf("jitter(-1s) = %v, want -1s unchanged", got)
+	}
+}
+
+func TestConnectOnceFallsBackToWebSocketOnSSEUnsupported(t *testing.T) {
+	wsEvents := make(chan Event, 1)
+	wsEvents <- Event{Type: "created", Product: inventory.Product{ID: "1"}}
+	close(wsEvents)
+
+	w := &Watcher{
+		DialSSE: func(ctx context.Context) (*http.Response, error) {
+			return sseResponse(http.StatusNotFound, "text/plain", ""), nil
+		},
+		DialWS: func(ctx context.Context) (<-chan Event, error) {
+			return wsEvents, nil
+		},
+	}
+
+	events := make(chan Event)
+	done := make(chan error, 1)
+	go func() { done <- w.connectOnce(context.Background(), events) }()
+
+	select {
+	case evt := <-events:
+		if evt.Product.ID != "1" {
+			t.Fatalf("got event %+v, want product id 1", evt)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the websocket-relayed event")
+	}
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected an error once the websocket fallback stream closes")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("connectOnce did not return after the websocket channel closed")
+	}
+}
+
+func TestConnectOnceReportsBothTransportsFailing(t *testing.T) {
+	w := &Watcher{
+		DialSSE: func(ctx context.Context) (*http.Response, error) {
+			return nil, errors.New("sse unreachable")
+		},
+		DialWS: func(ctx context.Context) (<-chan Event, error) {
+			return nil, errors.New("websocket unreachable")
+		},
+	}
+
+	err := w.connectOnce(context.Background(), make(chan Event))
+	if err == nil {
+		t.Fatal("expected an error when both SSE and WebSocket fail")
+	}
+}
+
+func TestWatchStopsPromptlyOnContextCancellation(t *testing.T) {
+	w := &Watcher{
+		DialSSE: func(ctx context.Context) (*http.Response, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+		MinBackoff: time.Second,
+		MaxBackoff: time.Second,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := w.Watch(ctx)
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("expected the events channel to close, not emit an event")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Watch did not shut down promptly after context cancellation")
+	}
+}
+
+func TestWatchRetriesWithinBackoffCap(t *testing.T) {
+	var attempts int32
+	w := &Watcher{
+		DialSSE: func(ctx context.Context) (*http.Response, error) {
+			atomic.AddInt32(&attempts, 1)
+			return nil, errors.New("connection refused")
+		},
+		MinBackoff: 5 * time.Millisecond,
+		MaxBackoff: 20 * time.Millisecond,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+
+	events := w.Watch(ctx)
+	for range events {
+	}
+
+	if got := atomic.LoadInt32(&attempts); got < 3 {
+		t.Fatalf("attempts = %d, want at least 3 reconnect attempts once backoff is capped at %v", got, w.MaxBackoff)
+	}
+}
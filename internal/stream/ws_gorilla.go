@@ -0,0 +1,46 @@
+//go:build websocket
+
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+)
+
+// GorillaWSDialer builds a WSDialer backed by gorilla/websocket, connecting
+// to wsURL with the given headers (typically Authorization). Only compiled
+// in with `-tags websocket`, since that module isn't a dependency of the
+// default build.
+func GorillaWSDialer(wsURL string, headers http.Header) WSDialer {
+	return func(ctx context.Context) (<-chan Event, error) {
+		conn, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL, headers)
+		if err != nil {
+			return nil, err
+		}
+
+		events := make(chan Event)
+		go func() {
+			defer close(events)
+			defer conn.Close()
+			for {
+				_, data, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				var evt Event
+				if err := json.Unmarshal(data, &evt); err != nil {
+					continue
+				}
+				select {
+				case events <- evt:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		return events, nil
+	}
+}
@@ -0,0 +1,75 @@
+// Package syncengine replays a queue of pending mutations against the
+// server once connectivity is restored.
+package syncengine
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/amangirdhar210/inventoryClient/internal/store"
+)
+
+// RequestFunc performs a single HTTP request and reports the response body,
+// status code, and any transport-level error. Implementations are expected
+// to attach the headers supplied in addition to their own auth headers.
+type RequestFunc func(method, path string, payload any, headers map[string]string) ([]byte, int, error)
+
+// Result reports the outcome of replaying a single pending operation.
+type Result struct {
+	Operation  store.PendingOperation
+	StatusCode int
+	Err        error
+}
+
+// Replay sends every pending operation in ops, in order, via do. It stops
+// at the first operation that fails because the server is still
+// unreachable, returned an auth failure (401, e.g. a token that expired
+// while offline), or errored (5xx) — none of those mean the operation was
+// rejected, so the remaining queue (starting with that operation) is
+// preserved for the next attempt. It continues past operations the server
+// rejects outright (4xx other than 401), since those are unrecoverable and
+// should not block the rest of the queue.
+//
+// It returns the per-operation results for everything it attempted and the
+// set of idempotency keys that are done with: those the server applied (or
+// already had, 409) and those it rejected outright. The set is suitable for
+// passing to (*store.Journal).Prune.
+func Replay(ops []store.PendingOperation, do RequestFunc) ([]Result, map[string]bool) {
+	applied := make(map[string]bool)
+	results := make([]Result, 0, len(ops))
+
+	for _, op := range ops {
+		headers := map[string]string{"Idempotency-Key": op.IdempotencyKey}
+		for k, v := range op.Headers {
+			headers[k] = v
+		}
+
+		body, status, err := do(op.Method, op.Path, op.Payload, headers)
+		if err != nil {
+			results = append(results, Result{Operation: op, Err: fmt.Errorf("server still unreachable: %w", err)})
+			break
+		}
+
+		if status >= 200 && status < 300 || status == http.StatusConflict {
+			// A 409 means the server already has this idempotency key
+			// recorded, i.e. an earlier sync attempt already applied it.
+			applied[op.IdempotencyKey] = true
+			results = append(results, Result{Operation: op, StatusCode: status})
+			continue
+		}
+
+		if status == http.StatusUnauthorized || status >= 500 {
+			// The token expired while offline, or the server itself is
+			// erroring. Neither means this operation was rejected, so
+			// leave it queued for the next sync attempt instead of
+			// pruning it.
+			results = append(results, Result{Operation: op, StatusCode: status, Err: fmt.Errorf("not applied, will retry: status %d", status)})
+			break
+		}
+
+		results = append(results, Result{Operation: op, StatusCode: status, Err: fmt.Errorf("rejected: %s", body)})
+		applied[op.IdempotencyKey] = true
+	}
+
+	return results, applied
+}
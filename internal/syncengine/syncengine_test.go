@@ -0,0 +1,167 @@
+package syncengine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/amangirdhar210/inventoryClient/internal/store"
+)
+
+func TestReplayStopsAtFirstUnreachable(t *testing.T) {
+	ops := []store.PendingOperation{
+		{IdempotencyKey: "a", Method: "POST", Path: "/api/products"},
+		{IdempotencyKey: "b", Method: "POST", Path: "/api/products"},
+		{IdempotencyKey: "c", Method: "POST", Path: "/api/products"},
+	}
+
+	var calls int
+	do := func(method, path string, payload any, headers map[string]string) ([]byte, int, error) {
+		calls++
+		if calls == 2 {
+			return nil, 0, errors.New("connection refused")
+		}
+		return nil, 201, nil
+	}
+
+	results, applied := Replay(ops, do)
+
+	if calls != 2 {
+		t.Fatalf("do called %d times, want 2 (replay should stop at the unreachable op)", calls)
+	}
+	if len(results) != 2 {
+		t.Fatalf("len(results) = %d, want 2", len(results))
+	}
+	if results[1].Err == nil {
+		t.Error("expected the second result to carry the unreachable error")
+	}
+	if applied["a"] != true || applied["c"] == true {
+		t.Errorf("applied = %v, want only %q applied", applied, "a")
+	}
+}
+
+func TestReplayStopsAndKeepsQueuedOnAuthFailure(t *testing.T) {
+	ops := []store.PendingOperation{
+		{IdempotencyKey: "a", Method: "POST", Path: "/api/products"},
+		{IdempotencyKey: "b", Method: "POST", Path: "/api/products"},
+	}
+
+	var calls int
+	do := func(method, path string, payload any, headers map[string]string) ([]byte, int, error) {
+		calls++
+		if calls == 2 {
+			return nil, 401, nil
+		}
+		return nil, 201, nil
+	}
+
+	results, applied := Replay(ops, do)
+
+	if calls != 2 {
+		t.Fatalf("do called %d times, want 2 (replay should stop at the 401)", calls)
+	}
+	if results[1].Err == nil {
+		t.Error("expected the 401 result to carry an error")
+	}
+	if applied["b"] {
+		t.Error("a 401 should not mark the operation as applied; the token may just have expired offline")
+	}
+	if !applied["a"] {
+		t.Error("the earlier successful operation should still be applied")
+	}
+}
+
+func TestReplayStopsAndKeepsQueuedOnServerError(t *testing.T) {
+	ops := []store.PendingOperation{
+		{IdempotencyKey: "a", Method: "POST", Path: "/api/products"},
+		{IdempotencyKey: "b", Method: "POST", Path: "/api/products"},
+	}
+
+	var calls int
+	do := func(method, path string, payload any, headers map[string]string) ([]byte, int, error) {
+		calls++
+		if calls == 1 {
+			return nil, 500, nil
+		}
+		return nil, 201, nil
+	}
+
+	results, applied := Replay(ops, do)
+
+	if calls != 1 {
+		t.Fatalf("do called %d times, want 1 (replay should stop at the 500 instead of trying later ops)", calls)
+	}
+	if len(results) != 1 || results[0].Err == nil {
+		t.Fatalf("got results=%+v, want one result carrying an error", results)
+	}
+	if applied["a"] {
+		t.Error("a 500 should not mark the operation as applied; it should be retried next sync")
+	}
+}
+
+func TestReplayTreatsConflictAsApplied(t *testing.T) {
+	ops := []store.PendingOperation{
+		{IdempotencyKey: "dup", Method: "POST", Path: "/api/products"},
+	}
+
+	do := func(method, path string, payload any, headers map[string]string) ([]byte, int, error) {
+		return nil, 409, nil
+	}
+
+	results, applied := Replay(ops, do)
+
+	if len(results) != 1 || results[0].Err != nil {
+		t.Fatalf("got results=%+v, want one result with no error", results)
+	}
+	if !applied["dup"] {
+		t.Error("a 409 response should mark the idempotency key as applied")
+	}
+}
+
+func TestReplayForwardsCustomHeaders(t *testing.T) {
+	ops := []store.PendingOperation{
+		{IdempotencyKey: "k", Method: "PATCH", Path: "/api/products/1", Headers: map[string]string{"If-Match": "etag-123"}},
+	}
+
+	var gotHeaders map[string]string
+	do := func(method, path string, payload any, headers map[string]string) ([]byte, int, error) {
+		gotHeaders = headers
+		return nil, 200, nil
+	}
+
+	Replay(ops, do)
+
+	if gotHeaders["If-Match"] != "etag-123" {
+		t.Errorf("If-Match header = %q, want %q", gotHeaders["If-Match"], "etag-123")
+	}
+	if gotHeaders["Idempotency-Key"] != "k" {
+		t.Errorf("Idempotency-Key header = %q, want %q", gotHeaders["Idempotency-Key"], "k")
+	}
+}
+
+func TestReplayContinuesPastRejection(t *testing.T) {
+	ops := []store.PendingOperation{
+		{IdempotencyKey: "bad", Method: "POST", Path: "/api/products"},
+		{IdempotencyKey: "good", Method: "POST", Path: "/api/products"},
+	}
+
+	var calls int
+	do := func(method, path string, payload any, headers map[string]string) ([]byte, int, error) {
+		calls++
+		if calls == 1 {
+			return []byte(`{"error":"invalid"}`), 400, nil
+		}
+		return nil, 201, nil
+	}
+
+	results, applied := Replay(ops, do)
+
+	if calls != 2 {
+		t.Fatalf("do called %d times, want 2 (a rejection should not halt the replay)", calls)
+	}
+	if results[0].Err == nil {
+		t.Error("expected the rejected operation to carry an error")
+	}
+	if !applied["bad"] || !applied["good"] {
+		t.Errorf("applied = %v, want both keys present", applied)
+	}
+}